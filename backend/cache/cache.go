@@ -0,0 +1,53 @@
+// Package cache provides a pluggable cache subsystem for upstream API
+// responses (movie data, streaming options, actor lists). Callers choose
+// a backend (in-memory LRU or filesystem-backed JSON) behind the Store
+// interface so the fetch layer doesn't need to know how entries are kept
+// or expired.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Get when the key is absent or its entry has
+// expired.
+var ErrNotFound = errors.New("cache: key not found")
+
+// Store is a pluggable cache backend. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get unmarshals the cached value for key into v. It returns
+	// ErrNotFound if the key is missing or its TTL has elapsed.
+	Get(key string, v any) error
+	// Set stores v under key, recording the fetch time so it can expire
+	// after ttl. A zero ttl means the entry never expires.
+	Set(key string, v any, ttl time.Duration) error
+	// Delete removes key from the store, if present.
+	Delete(key string)
+}
+
+// entry is the on-disk/in-memory envelope around a cached value. It
+// records when the value was fetched and its per-entry TTL so stores can
+// decide expiry without depending on wall-clock-free storage formats.
+type entry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	TTL       time.Duration   `json:"ttl"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func (e entry) expired() bool {
+	if e.TTL <= 0 {
+		return false
+	}
+	return time.Since(e.FetchedAt) > e.TTL
+}
+
+func newEntry(v any, ttl time.Duration) (entry, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return entry{}, err
+	}
+	return entry{FetchedAt: time.Now(), TTL: ttl, Data: data}, nil
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// FileStore is a Store backed by one JSON file per key underneath Dir.
+// It trades the speed of an in-memory cache for surviving process
+// restarts, which suits slow-changing upstream data like base movie
+// info.
+type FileStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// NewFileStore creates a FileStore rooted at dir, creating the directory
+// if it doesn't already exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.Dir, unsafeKeyChars.ReplaceAllString(key, "_")+".json")
+}
+
+func (s *FileStore) Get(key string, v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return err
+	}
+	if e.expired() {
+		_ = os.Remove(s.path(key))
+		return ErrNotFound
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+func (s *FileStore) Set(key string, v any, ttl time.Duration) error {
+	e, err := newEntry(v, ttl)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(key))
+}
+
+func (s *FileStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = os.Remove(s.path(key))
+}
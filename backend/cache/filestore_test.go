@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStoreSetGet(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if err := s.Set("movie:tt1", "hello", time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	var got string
+	if err := s.Get("movie:tt1", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got != "hello" {
+		t.Fatalf("Get() = %q, want %q", got, "hello")
+	}
+}
+
+func TestFileStoreGetMissingKey(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	var v string
+	if err := s.Get("missing", &v); err != ErrNotFound {
+		t.Fatalf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStoreSanitizesKeyForFilename(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if err := s.Set("movie:tt1/special?", "v", time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("ReadDir() = %d entries, want 1", len(entries))
+	}
+	if name := entries[0].Name(); strings.ContainsAny(name, ":/?") {
+		t.Fatalf("filename %q was not sanitized", name)
+	}
+}
+
+func TestFileStoreSetLeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if err := s.Set("a", "v", time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Fatalf("Set() left a temp file behind: %s", e.Name())
+		}
+	}
+}
+
+func TestFileStoreDeleteMissingKeyIsNoop(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	s.Delete("missing")
+}
+
+func TestFileStoreGetAfterDeletePersistsAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := s.Set("a", "v", time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	s.Delete("a")
+
+	// A fresh FileStore over the same directory simulates a process
+	// restart; the deletion must have actually hit disk.
+	restarted, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() on restart error = %v", err)
+	}
+	var v string
+	if err := restarted.Get("a", &v); err != ErrNotFound {
+		t.Fatalf("Get() after restart error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStoreGetExpired(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := s.Set("a", "v", 10*time.Millisecond); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	var v string
+	if err := s.Get("a", &v); err != ErrNotFound {
+		t.Fatalf("Get() after TTL elapsed error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// LRUStore is an in-memory Store bounded by a maximum entry count. When
+// full, the least recently used entry is evicted to make room for a new
+// one.
+type LRUStore struct {
+	mu       sync.Mutex
+	maxItems int
+	items    map[string]*list.Element
+	order    *list.List // front = most recently used
+}
+
+type lruNode struct {
+	key   string
+	entry entry
+}
+
+// NewLRUStore creates an in-memory LRU store holding at most maxItems
+// entries. A maxItems <= 0 is treated as unbounded.
+func NewLRUStore(maxItems int) *LRUStore {
+	return &LRUStore{
+		maxItems: maxItems,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *LRUStore) Get(key string, v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return ErrNotFound
+	}
+	node := el.Value.(*lruNode)
+	if node.entry.expired() {
+		s.removeElement(el)
+		return ErrNotFound
+	}
+	s.order.MoveToFront(el)
+	return json.Unmarshal(node.entry.Data, v)
+}
+
+func (s *LRUStore) Set(key string, v any, ttl time.Duration) error {
+	e, err := newEntry(v, ttl)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		el.Value.(*lruNode).entry = e
+		s.order.MoveToFront(el)
+		return nil
+	}
+
+	el := s.order.PushFront(&lruNode{key: key, entry: e})
+	s.items[key] = el
+
+	if s.maxItems > 0 {
+		for s.order.Len() > s.maxItems {
+			s.removeElement(s.order.Back())
+		}
+	}
+	return nil
+}
+
+func (s *LRUStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		s.removeElement(el)
+	}
+}
+
+// removeElement must be called with s.mu held.
+func (s *LRUStore) removeElement(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.items, el.Value.(*lruNode).key)
+}
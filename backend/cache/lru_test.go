@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLRUStoreSetGet(t *testing.T) {
+	s := NewLRUStore(10)
+
+	if err := s.Set("a", "hello", time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	var got string
+	if err := s.Get("a", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got != "hello" {
+		t.Fatalf("Get() = %q, want %q", got, "hello")
+	}
+}
+
+func TestLRUStoreEvictsLeastRecentlyUsedAtBoundary(t *testing.T) {
+	s := NewLRUStore(2)
+
+	mustSet(t, s, "a", "1")
+	mustSet(t, s, "b", "2")
+	mustSet(t, s, "c", "3") // over the boundary; "a" is least recently used
+
+	var v string
+	if err := s.Get("a", &v); err != ErrNotFound {
+		t.Fatalf("Get(a) error = %v, want ErrNotFound", err)
+	}
+	if err := s.Get("b", &v); err != nil {
+		t.Fatalf("Get(b) error = %v", err)
+	}
+	if err := s.Get("c", &v); err != nil {
+		t.Fatalf("Get(c) error = %v", err)
+	}
+}
+
+func TestLRUStoreGetRefreshesRecency(t *testing.T) {
+	s := NewLRUStore(2)
+
+	mustSet(t, s, "a", "1")
+	mustSet(t, s, "b", "2")
+
+	var v string
+	if err := s.Get("a", &v); err != nil {
+		t.Fatalf("Get(a) error = %v", err)
+	}
+
+	mustSet(t, s, "c", "3") // "b" is now least recently used, not "a"
+
+	if err := s.Get("b", &v); err != ErrNotFound {
+		t.Fatalf("Get(b) error = %v, want ErrNotFound", err)
+	}
+	if err := s.Get("a", &v); err != nil {
+		t.Fatalf("Get(a) error = %v, want a to survive eviction", err)
+	}
+}
+
+func TestLRUStoreUnboundedWhenMaxItemsNotPositive(t *testing.T) {
+	s := NewLRUStore(0)
+
+	for i := 0; i < 50; i++ {
+		mustSet(t, s, fmt.Sprintf("key-%d", i), i)
+	}
+	if got := s.order.Len(); got != 50 {
+		t.Fatalf("order.Len() = %d, want 50 (no eviction when maxItems <= 0)", got)
+	}
+}
+
+func TestLRUStoreDelete(t *testing.T) {
+	s := NewLRUStore(10)
+	mustSet(t, s, "a", "1")
+
+	s.Delete("a")
+
+	var v string
+	if err := s.Get("a", &v); err != ErrNotFound {
+		t.Fatalf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+
+	// Deleting an already-absent key must not panic.
+	s.Delete("missing")
+}
+
+func TestEntryExpiredBoundary(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+	const margin = 10 * time.Millisecond
+
+	withinTTL := entry{FetchedAt: time.Now().Add(-(ttl - margin)), TTL: ttl}
+	if withinTTL.expired() {
+		t.Fatalf("expired() = true for an entry still within its TTL")
+	}
+
+	pastTTL := entry{FetchedAt: time.Now().Add(-(ttl + margin)), TTL: ttl}
+	if !pastTTL.expired() {
+		t.Fatalf("expired() = false for an entry past its TTL")
+	}
+
+	neverExpires := entry{FetchedAt: time.Now().Add(-999 * time.Hour), TTL: 0}
+	if neverExpires.expired() {
+		t.Fatalf("expired() = true for a zero-TTL entry, want it to never expire")
+	}
+}
+
+func TestLRUStoreGetExpired(t *testing.T) {
+	s := NewLRUStore(10)
+	if err := s.Set("a", "1", 10*time.Millisecond); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	var v string
+	if err := s.Get("a", &v); err != ErrNotFound {
+		t.Fatalf("Get() after TTL elapsed error = %v, want ErrNotFound", err)
+	}
+}
+
+func mustSet(t *testing.T, s *LRUStore, key string, v any) {
+	t.Helper()
+	if err := s.Set(key, v, time.Hour); err != nil {
+		t.Fatalf("Set(%s) error = %v", key, err)
+	}
+}
@@ -0,0 +1,234 @@
+// Command worker periodically walks the movie store for stale
+// user-curated records and refreshes their upstream data (base info,
+// actors, streaming options) through the same rate-limited fetcher the
+// API uses, so the cache stays warm without every request paying for a
+// cold fetch. It also serves a small HTTP API for forcing an immediate,
+// out-of-band refresh of a single record.
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/joho/godotenv"
+
+	"github.com/michaelamckanna/movieapp/backend/cache"
+	"github.com/michaelamckanna/movieapp/backend/fetcher"
+	"github.com/michaelamckanna/movieapp/backend/moviestore"
+)
+
+const (
+	defaultRapidAPIRPS = 5
+	defaultInterval    = 1 * time.Hour
+	defaultStaleAfter  = 24 * time.Hour
+	refreshTimeout     = 15 * time.Second
+)
+
+var (
+	log         *slog.Logger
+	dataFetcher *fetcher.Fetcher
+	movieStore  *moviestore.Store
+	adminToken  string
+)
+
+func init() {
+	log = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	if err := godotenv.Load(); err != nil {
+		log.Warn("no .env file loaded", "error", err)
+	}
+
+	dir := os.Getenv("CACHE_DIR")
+	if dir == "" {
+		log.Error("CACHE_DIR is required: the worker only refreshes the cache the API reads from, and without a shared on-disk CACHE_DIR its refreshes would land in a private in-process cache the API never sees")
+		os.Exit(1)
+	}
+	dataStore, err := cache.NewFileStore(dir)
+	if err != nil {
+		log.Error("creating file cache", "dir", dir, "error", err)
+		os.Exit(1)
+	}
+
+	rps := defaultRapidAPIRPS
+	if v := os.Getenv("RAPIDAPI_RPS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			rps = parsed
+		}
+	}
+
+	dataFetcher = fetcher.New(fetcher.Config{
+		MovieAPIBaseURL:     os.Getenv("MOVIE_API_URL"),
+		MovieAPIHost:        os.Getenv("MOVIE_API_HOST"),
+		StreamingAPIBaseURL: os.Getenv("STREAMING_API_URL"),
+		StreamingAPIHost:    os.Getenv("STREAMING_API_HOST"),
+		RapidAPIKey:         os.Getenv("RAPID_API_KEY"),
+		RPS:                 rps,
+		StreamingCountries:  parseStreamingCountries(os.Getenv("STREAMING_COUNTRIES")),
+	}, dataStore)
+
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = "movieapp.db"
+	}
+	store, err := moviestore.Open(path)
+	if err != nil {
+		log.Error("opening movie store", "path", path, "error", err)
+		os.Exit(1)
+	}
+	movieStore = store
+
+	adminToken = os.Getenv("ADMIN_TOKEN")
+}
+
+func main() {
+	defer movieStore.Close()
+
+	go serveAdmin()
+
+	interval := envDuration("REFRESH_INTERVAL", defaultInterval)
+	staleAfter := envDuration("STALE_AFTER", defaultStaleAfter)
+
+	log.Info("worker starting", "refreshInterval", interval, "staleAfter", staleAfter)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	refreshStale(context.Background(), staleAfter)
+	for range ticker.C {
+		refreshStale(context.Background(), staleAfter)
+	}
+}
+
+// refreshStale refreshes every record whose updated_at is older than
+// staleAfter, logging how long each stage took so slow upstream
+// endpoints are easy to spot.
+func refreshStale(ctx context.Context, staleAfter time.Duration) {
+	start := time.Now()
+
+	records, err := movieStore.ListStale(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		log.Error("listing stale records", "error", err)
+		return
+	}
+
+	log.Info("stale sweep starting", "count", len(records))
+
+	var refreshed, failed int
+	for _, rec := range records {
+		if err := refreshRecord(ctx, rec.ID); err != nil {
+			log.Error("refreshing record failed", "id", rec.ID, "error", err)
+			failed++
+			continue
+		}
+		refreshed++
+	}
+
+	log.Info("stale sweep finished", "refreshed", refreshed, "failed", failed, "duration", time.Since(start))
+}
+
+// refreshRecord force-refreshes id's movie and streaming data and
+// touches its moviestore record so it drops off the next stale sweep.
+func refreshRecord(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+
+	stageStart := time.Now()
+	if _, err := dataFetcher.RefreshMovieData(ctx, id); err != nil {
+		return err
+	}
+	log.Info("refreshed movie data", "id", id, "duration", time.Since(stageStart))
+
+	stageStart = time.Now()
+	if _, err := dataFetcher.RefreshStreamingOptions(ctx, id); err != nil {
+		log.Warn("refreshing streaming options failed", "id", id, "error", err)
+	} else {
+		log.Info("refreshed streaming options", "id", id, "duration", time.Since(stageStart))
+	}
+
+	return movieStore.Touch(ctx, id, time.Now().UTC())
+}
+
+// serveAdmin runs a small HTTP server exposing on-demand refreshes,
+// gated by ADMIN_TOKEN. If ADMIN_TOKEN isn't set the endpoint always
+// rejects requests rather than running unauthenticated.
+func serveAdmin() {
+	r := mux.NewRouter()
+	r.HandleFunc("/admin/refresh/{id}", handleAdminRefresh).Methods("POST")
+
+	port := os.Getenv("WORKER_PORT")
+	if port == "" {
+		port = "8081"
+	}
+
+	log.Info("admin server starting", "port", port)
+	log.Error("admin server exited", "error", http.ListenAndServe(":"+port, r))
+}
+
+func handleAdminRefresh(w http.ResponseWriter, r *http.Request) {
+	if adminToken == "" || !tokensMatch(r.Header.Get("ADMIN_TOKEN"), adminToken) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	if _, err := movieStore.Get(r.Context(), id); err != nil {
+		if errors.Is(err, moviestore.ErrNotFound) {
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := refreshRecord(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tokensMatch compares two tokens in constant time so a timing
+// difference in a failed comparison can't leak adminToken byte by byte.
+func tokensMatch(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		log.Warn("invalid duration, using default", "key", key, "value", v, "default", fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// parseStreamingCountries mirrors backend's streamingCountriesFromEnv;
+// the worker can't import that unexported helper from package main, so
+// it keeps its own copy in sync with STREAMING_COUNTRIES.
+func parseStreamingCountries(v string) []string {
+	var countries []string
+	for _, c := range strings.Split(v, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" {
+			countries = append(countries, c)
+		}
+	}
+	if len(countries) == 0 {
+		return []string{"us"}
+	}
+	return countries
+}
@@ -0,0 +1,128 @@
+// Package fetcher fetches movie, actor, and streaming-availability data
+// from the upstream RapidAPI endpoints, caching results and sharing one
+// rate limiter and request-coalescing group across every caller. Both
+// the HTTP API and the background refresh worker use it so they never
+// bypass each other's throttling.
+package fetcher
+
+import (
+	"context"
+	"log"
+	"time"
+
+	streaming "github.com/movieofthenight/go-streaming-availability/v4"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+
+	"github.com/michaelamckanna/movieapp/backend/cache"
+)
+
+// MovieTTL and StreamingTTL are the per-endpoint cache lifetimes: base
+// movie data rarely changes, but streaming availability does.
+const (
+	MovieTTL     = 24 * time.Hour
+	StreamingTTL = 1 * time.Hour
+)
+
+// Config holds everything needed to talk to the upstream RapidAPI
+// endpoints.
+type Config struct {
+	MovieAPIBaseURL     string
+	MovieAPIHost        string
+	StreamingAPIBaseURL string
+	StreamingAPIHost    string
+	RapidAPIKey         string
+	RPS                 int
+	StreamingCountries  []string
+}
+
+// Fetcher fetches movie/streaming/actor data from upstream, caching
+// results in Store and sharing one rate limiter and singleflight group
+// across every caller.
+type Fetcher struct {
+	cfg             Config
+	store           cache.Store
+	limiter         *rate.Limiter
+	group           singleflight.Group
+	streamingClient *streaming.APIClient
+}
+
+// New builds a Fetcher backed by store, rate-limited to cfg.RPS requests
+// per second across all of MovieData, StreamingOptions, and the main
+// actors lookup.
+func New(cfg Config, store cache.Store) *Fetcher {
+	streamingCfg := streaming.NewConfiguration()
+	if cfg.StreamingAPIBaseURL != "" {
+		streamingCfg.Servers = streaming.ServerConfigurations{{URL: cfg.StreamingAPIBaseURL}}
+	}
+	streamingCfg.AddDefaultHeader("x-rapidapi-host", cfg.StreamingAPIHost)
+	streamingCfg.AddDefaultHeader("x-rapidapi-key", cfg.RapidAPIKey)
+
+	return &Fetcher{
+		cfg:             cfg,
+		store:           store,
+		limiter:         rate.NewLimiter(rate.Limit(cfg.RPS), cfg.RPS),
+		streamingClient: streaming.NewAPIClient(streamingCfg),
+	}
+}
+
+func movieCacheKey(id string) string     { return "movie:" + id }
+func streamingCacheKey(id string) string { return "streaming:" + id }
+
+// MovieData returns id's base movie data (with actors), serving from
+// cache when fresh.
+func (f *Fetcher) MovieData(ctx context.Context, id string) (Movie, error) {
+	var movie Movie
+	if err := f.store.Get(movieCacheKey(id), &movie); err == nil {
+		return movie, nil
+	}
+	return f.RefreshMovieData(ctx, id)
+}
+
+// RefreshMovieData always hits upstream and refreshes the cache,
+// regardless of whether a cached value already exists. Concurrent
+// refreshes for the same ID are coalesced into a single upstream call.
+func (f *Fetcher) RefreshMovieData(ctx context.Context, id string) (Movie, error) {
+	v, err, _ := f.group.Do(movieCacheKey(id), func() (any, error) {
+		m, err := f.rawMovieData(ctx, id)
+		if err != nil {
+			return Movie{}, err
+		}
+		if err := f.store.Set(movieCacheKey(id), m, MovieTTL); err != nil {
+			log.Printf("fetcher: caching movie data for %s: %v", id, err)
+		}
+		return m, nil
+	})
+	if err != nil {
+		return Movie{}, err
+	}
+	return v.(Movie), nil
+}
+
+// StreamingOptions returns id's streaming availability across every
+// configured country, serving from cache when fresh.
+func (f *Fetcher) StreamingOptions(ctx context.Context, id string) ([]StreamingOption, error) {
+	var options []StreamingOption
+	if err := f.store.Get(streamingCacheKey(id), &options); err == nil {
+		return options, nil
+	}
+	return f.RefreshStreamingOptions(ctx, id)
+}
+
+// RefreshStreamingOptions always hits upstream and refreshes the cache.
+func (f *Fetcher) RefreshStreamingOptions(ctx context.Context, id string) ([]StreamingOption, error) {
+	v, err, _ := f.group.Do(streamingCacheKey(id), func() (any, error) {
+		opts, err := f.rawStreamingOptions(ctx, id)
+		if err != nil {
+			return []StreamingOption(nil), err
+		}
+		if err := f.store.Set(streamingCacheKey(id), opts, StreamingTTL); err != nil {
+			log.Printf("fetcher: caching streaming options for %s: %v", id, err)
+		}
+		return opts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]StreamingOption), nil
+}
@@ -0,0 +1,91 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+func (f *Fetcher) rawMovieData(ctx context.Context, id string) (Movie, error) {
+	url := fmt.Sprintf("%s/titles/%s?info=base_info", f.cfg.MovieAPIBaseURL, id)
+
+	if err := f.limiter.Wait(ctx); err != nil {
+		return Movie{}, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	log.Printf("Fetching movie data from URL: %s", url)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return Movie{}, err
+	}
+	req.Header.Add("x-rapidapi-host", f.cfg.MovieAPIHost)
+	req.Header.Add("x-rapidapi-key", f.cfg.RapidAPIKey)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Movie{}, fmt.Errorf("fetching movie data for %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	log.Printf("Received movie data response: %s", string(body))
+
+	var movieResp struct {
+		Results Movie `json:"results"`
+	}
+	if err := json.Unmarshal(body, &movieResp); err != nil {
+		return Movie{}, fmt.Errorf("parsing movie data for %s: %w", id, err)
+	}
+
+	movie := movieResp.Results
+	actors, err := f.rawMainActors(ctx, id)
+	if err != nil {
+		log.Printf("Error fetching main actors for %s: %v", id, err)
+	} else {
+		movie.MainActors = actors
+	}
+	return movie, nil
+}
+
+func (f *Fetcher) rawMainActors(ctx context.Context, id string) ([]Actor, error) {
+	url := fmt.Sprintf("%s/titles/%s/main_actors", f.cfg.MovieAPIBaseURL, id)
+
+	if err := f.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("x-rapidapi-host", f.cfg.MovieAPIHost)
+	req.Header.Add("x-rapidapi-key", f.cfg.RapidAPIKey)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching main actors for %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+
+	var actorsResp struct {
+		Results []struct {
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &actorsResp); err != nil {
+		return nil, fmt.Errorf("parsing main actors for %s: %w", id, err)
+	}
+
+	var actors []Actor
+	for _, result := range actorsResp.Results {
+		actors = append(actors, Actor{Name: result.Name})
+	}
+
+	return actors, nil
+}
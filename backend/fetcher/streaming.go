@@ -0,0 +1,60 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	streaming "github.com/movieofthenight/go-streaming-availability/v4"
+)
+
+// rawStreamingOptions fetches availability for id across every
+// configured country and flattens the per-country results into one
+// slice, each entry tagged with the country it came from.
+func (f *Fetcher) rawStreamingOptions(ctx context.Context, id string) ([]StreamingOption, error) {
+	var all []StreamingOption
+
+	for _, country := range f.cfg.StreamingCountries {
+		if err := f.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+
+		show, resp, err := f.streamingClient.ShowsAPI.GetShow(ctx, id).Country(country).Execute()
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("fetching streaming options for %s (%s): %w", id, country, err)
+		}
+
+		for _, opt := range show.StreamingOptions[country] {
+			all = append(all, convertStreamingOption(country, opt))
+		}
+	}
+
+	return all, nil
+}
+
+func convertStreamingOption(country string, opt streaming.StreamingOption) StreamingOption {
+	converted := StreamingOption{
+		Service: opt.Service.Id,
+		Type:    string(opt.Type),
+		Country: country,
+		Link:    opt.Link,
+	}
+	if opt.Quality != nil {
+		converted.Quality = *opt.Quality
+	}
+	for _, audio := range opt.Audios {
+		converted.AudioLanguages = append(converted.AudioLanguages, audio.Language)
+	}
+	if opt.Price != nil {
+		converted.Price = &Price{Amount: opt.Price.Amount, Currency: opt.Price.Currency}
+	}
+	if opt.ExpiresOn != nil {
+		t := time.Unix(*opt.ExpiresOn, 0).UTC()
+		converted.ExpiresOn = &t
+	}
+	return converted
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"testing"
+	"time"
+
+	streaming "github.com/movieofthenight/go-streaming-availability/v4"
+)
+
+func TestConvertStreamingOptionFullyPopulated(t *testing.T) {
+	quality := "hd"
+	expiresOn := int64(1700000000)
+	opt := streaming.StreamingOption{
+		Service: streaming.ServiceInfo{Id: "netflix"},
+		Type:    streaming.StreamingOptionType("subscription"),
+		Link:    "https://example.com/watch",
+		Quality: &quality,
+		Audios: []streaming.Locale{
+			{Language: "eng"},
+			{Language: "spa"},
+		},
+		Price:     &streaming.Price{Amount: "3.99", Currency: "USD"},
+		ExpiresOn: &expiresOn,
+	}
+
+	got := convertStreamingOption("us", opt)
+
+	if got.Service != "netflix" || got.Type != "subscription" || got.Country != "us" || got.Link != opt.Link {
+		t.Fatalf("convertStreamingOption() = %+v, want service/type/country/link carried over", got)
+	}
+	if got.Quality != "hd" {
+		t.Fatalf("Quality = %q, want %q", got.Quality, "hd")
+	}
+	if len(got.AudioLanguages) != 2 || got.AudioLanguages[0] != "eng" || got.AudioLanguages[1] != "spa" {
+		t.Fatalf("AudioLanguages = %+v, want [eng spa]", got.AudioLanguages)
+	}
+	if got.Price == nil || got.Price.Amount != "3.99" || got.Price.Currency != "USD" {
+		t.Fatalf("Price = %+v, want {3.99 USD}", got.Price)
+	}
+	wantExpires := time.Unix(expiresOn, 0).UTC()
+	if got.ExpiresOn == nil || !got.ExpiresOn.Equal(wantExpires) {
+		t.Fatalf("ExpiresOn = %v, want %v", got.ExpiresOn, wantExpires)
+	}
+}
+
+func TestConvertStreamingOptionOmitsOptionalFields(t *testing.T) {
+	opt := streaming.StreamingOption{
+		Service: streaming.ServiceInfo{Id: "tubi"},
+		Type:    streaming.StreamingOptionType("free"),
+		Link:    "https://example.com/free",
+	}
+
+	got := convertStreamingOption("gb", opt)
+
+	if got.Quality != "" {
+		t.Fatalf("Quality = %q, want empty when upstream omits it", got.Quality)
+	}
+	if got.AudioLanguages != nil {
+		t.Fatalf("AudioLanguages = %+v, want nil when upstream omits audios", got.AudioLanguages)
+	}
+	if got.Price != nil {
+		t.Fatalf("Price = %+v, want nil when upstream omits it", got.Price)
+	}
+	if got.ExpiresOn != nil {
+		t.Fatalf("ExpiresOn = %v, want nil when upstream omits it", got.ExpiresOn)
+	}
+}
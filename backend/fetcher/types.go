@@ -0,0 +1,57 @@
+package fetcher
+
+import "time"
+
+// Movie is the movie data surfaced by the API, combining upstream
+// RapidAPI data with any user-curated fields merged in by the caller.
+type Movie struct {
+	ID               string            `json:"id"`
+	TitleText        string            `json:"titleText"`
+	TitleType        string            `json:"titleType"`
+	ReleaseYear      int               `json:"releaseYear"`
+	ReleaseDate      string            `json:"releaseDate"`
+	Genres           []string          `json:"genres"`
+	PrimaryImage     *PrimaryImage     `json:"primaryImage,omitempty"`
+	RatingsSummary   *RatingsSummary   `json:"ratingsSummary,omitempty"`
+	MainActors       []Actor           `json:"mainActors"`
+	StreamingOptions []StreamingOption `json:"streamingOptions,omitempty"`
+
+	// User-curated fields, merged in from moviestore when present.
+	Notes     string     `json:"notes,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Watched   bool       `json:"watched,omitempty"`
+	Rating    *float64   `json:"rating,omitempty"`
+	WatchedAt *time.Time `json:"watchedAt,omitempty"`
+}
+
+type PrimaryImage struct {
+	URL string `json:"url"`
+}
+
+type RatingsSummary struct {
+	AggregateRating float64 `json:"aggregateRating"`
+	VoteCount       int     `json:"voteCount"`
+}
+
+type Actor struct {
+	Name string `json:"name"`
+}
+
+// StreamingOption describes where and how a movie can be watched in a
+// single country, as returned by the streaming-availability API.
+type StreamingOption struct {
+	Service        string     `json:"service"`
+	Type           string     `json:"type"` // subscription, rent, buy, or free
+	Country        string     `json:"country"`
+	Link           string     `json:"link"`
+	Quality        string     `json:"quality,omitempty"`
+	AudioLanguages []string   `json:"audioLanguages,omitempty"`
+	Price          *Price     `json:"price,omitempty"`
+	ExpiresOn      *time.Time `json:"expiresOn,omitempty"`
+}
+
+// Price is the cost of a rent/buy streaming option.
+type Price struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
@@ -1,76 +1,98 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"io"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
-)
 
-type Movie struct {
-	ID              string          `json:"id"`
-	TitleText       string          `json:"titleText"`
-	TitleType       string          `json:"titleType"`
-	ReleaseYear     int             `json:"releaseYear"`
-	ReleaseDate     string          `json:"releaseDate"`
-	Genres          []string        `json:"genres"`
-	PrimaryImage    *PrimaryImage   `json:"primaryImage,omitempty"`
-	RatingsSummary  *RatingsSummary `json:"ratingsSummary,omitempty"`
-	MainActors      []Actor         `json:"mainActors"`
-	StreamingOptions []StreamingOption `json:"streamingOptions,omitempty"` 
-}
+	"github.com/michaelamckanna/movieapp/backend/cache"
+	"github.com/michaelamckanna/movieapp/backend/fetcher"
+	"github.com/michaelamckanna/movieapp/backend/moviestore"
+)
 
-type PrimaryImage struct {
-	URL string `json:"url"`
-}
+type (
+	Movie           = fetcher.Movie
+	PrimaryImage    = fetcher.PrimaryImage
+	RatingsSummary  = fetcher.RatingsSummary
+	Actor           = fetcher.Actor
+	StreamingOption = fetcher.StreamingOption
+	Price           = fetcher.Price
+)
 
-type RatingsSummary struct {
-	AggregateRating float64 `json:"aggregateRating"`
-	VoteCount       int     `json:"voteCount"`
+// FetchError reports that one stage of fetching a single movie failed,
+// so callers can tell a missing movie apart from, say, a failed
+// streaming-options lookup for an otherwise-valid movie.
+type FetchError struct {
+	ID      string `json:"id"`
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
 }
 
-type Actor struct {
-	Name string `json:"name"`
+// MoviesResponse is the /movies response shape: successfully fetched
+// movies alongside any per-stage failures encountered along the way.
+type MoviesResponse struct {
+	Movies []Movie      `json:"movies"`
+	Errors []FetchError `json:"errors"`
 }
 
-type MovieAPIResponse struct {
-	Results []Movie `json:"results"`
-}
+// defaultCacheSize bounds the in-memory LRU store used when CACHE_DIR
+// isn't set. defaultFetchConcurrency bounds the /movies worker pool when
+// FETCH_CONCURRENCY isn't set. fetchTimeout bounds how long a single
+// request waits on upstream calls before they're cancelled.
+const (
+	defaultCacheSize        = 500
+	defaultRapidAPIRPS      = 5
+	defaultFetchConcurrency = 8
+	fetchTimeout            = 15 * time.Second
+)
 
-type StreamingOption struct {
-	Service string `json:"service"`
-	URL     string `json:"url"`
-	Price   string `json:"price,omitempty"`
-	Quality string `json:"quality,omitempty"`
-}
+// dataFetcher fetches and caches upstream movie/streaming/actor data,
+// shared by every HTTP handler.
+var dataFetcher *fetcher.Fetcher
 
-var movieAPIBaseURL string
-var streamingAPIBaseURL string
-var movieAPIHost string
-var streamingAPIHost string
-var rapidAPIKey string
+func init() {
+	if err := godotenv.Load(); err != nil {
+		log.Printf("no .env file loaded: %v", err)
+	}
 
-// Simple in-memory cache
-var movieCache = make(map[string]Movie)
-var cacheMutex sync.RWMutex
+	var dataStore cache.Store
+	if dir := os.Getenv("CACHE_DIR"); dir != "" {
+		store, err := cache.NewFileStore(dir)
+		if err != nil {
+			log.Fatalf("Error creating file cache at %s: %v", dir, err)
+		}
+		dataStore = store
+	} else {
+		dataStore = cache.NewLRUStore(defaultCacheSize)
+	}
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	rps := defaultRapidAPIRPS
+	if v := os.Getenv("RAPIDAPI_RPS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			rps = parsed
+		}
 	}
 
-	movieAPIBaseURL = os.Getenv("MOVIE_API_URL")
-	streamingAPIBaseURL = os.Getenv("STREAMING_API_URL")
-	movieAPIHost = os.Getenv("MOVIE_API_HOST")
-	streamingAPIHost = os.Getenv("STREAMING_API_HOST")
-	rapidAPIKey = os.Getenv("RAPID_API_KEY")
+	dataFetcher = fetcher.New(fetcher.Config{
+		MovieAPIBaseURL:     os.Getenv("MOVIE_API_URL"),
+		MovieAPIHost:        os.Getenv("MOVIE_API_HOST"),
+		StreamingAPIBaseURL: os.Getenv("STREAMING_API_URL"),
+		StreamingAPIHost:    os.Getenv("STREAMING_API_HOST"),
+		RapidAPIKey:         os.Getenv("RAPID_API_KEY"),
+		RPS:                 rps,
+		StreamingCountries:  streamingCountriesFromEnv(),
+	}, dataStore)
+
+	movieStore = openMovieStore()
 }
 
 func main() {
@@ -81,7 +103,10 @@ func main() {
 	}).Methods("GET")
 
 	r.HandleFunc("/movies", getMovies).Methods("GET")
+	r.HandleFunc("/movies", postMovie).Methods("POST")
 	r.HandleFunc("/movies/{id}", getMovie).Methods("GET")
+	r.HandleFunc("/movies/{id}", patchMovie).Methods("PATCH")
+	r.HandleFunc("/movies/{id}", deleteMovie).Methods("DELETE")
 
 	r.Use(loggingMiddleware)
 
@@ -104,152 +129,105 @@ func loggingMiddleware(next http.Handler) http.Handler {
 func getMovies(w http.ResponseWriter, r *http.Request) {
 	// Assume we're receiving a list of movie IDs via a query parameter
 	movieIDs := r.URL.Query()["id"]
+	if len(movieIDs) == 0 {
+		listMovies(w, r)
+		return
+	}
+	filter := streamingFilterFromRequest(r)
 
-	var movies []Movie
-	for _, id := range movieIDs {
-		// Check cache first
-		cacheMutex.RLock()
-		movie, found := movieCache[id]
-		cacheMutex.RUnlock()
-
-		if !found {
-			// Fetch movie data
-			movie = fetchMovieData(id)
-			// Fetch streaming options
-			movie.StreamingOptions = fetchStreamingOptions(id)
-			// Cache the result
-			cacheMutex.Lock()
-			movieCache[id] = movie
-			cacheMutex.Unlock()
-		}
+	ctx, cancel := context.WithTimeout(r.Context(), fetchTimeout)
+	defer cancel()
 
-		movies = append(movies, movie)
-	}
+	movies, errs := fetchMoviesConcurrently(ctx, movieIDs, filter)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(movies)
+	json.NewEncoder(w).Encode(MoviesResponse{Movies: movies, Errors: errs})
 }
 
-func getMovie(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id := vars["id"]
-
-	// Check cache first
-	cacheMutex.RLock()
-	movie, found := movieCache[id]
-	cacheMutex.RUnlock()
-
-	if !found {
-		// Fetch movie data
-		movie = fetchMovieData(id)
-		// Fetch streaming options
-		movie.StreamingOptions = fetchStreamingOptions(id)
-		// Cache the result
-		cacheMutex.Lock()
-		movieCache[id] = movie
-		cacheMutex.Unlock()
+// fetchMoviesConcurrently fetches each ID's movie and streaming options
+// using a worker pool bounded by FETCH_CONCURRENCY, so a slow or failing
+// upstream call for one ID can't serialize the rest. Movies that fail to
+// fetch are omitted from the result and recorded in errs instead of
+// coming back as a zero-value Movie{}.
+func fetchMoviesConcurrently(ctx context.Context, ids []string, filter streamingFilter) ([]Movie, []FetchError) {
+	results := make([]*Movie, len(ids))
+	errsByIndex := make([][]FetchError, len(ids))
+
+	sem := make(chan struct{}, fetchConcurrency())
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errsByIndex[i] = fetchMovieWithErrors(ctx, id, filter)
+		}(i, id)
 	}
+	wg.Wait()
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(movie)
+	var movies []Movie
+	var errs []FetchError
+	for i := range ids {
+		if results[i] != nil {
+			movies = append(movies, *results[i])
+		}
+		errs = append(errs, errsByIndex[i]...)
+	}
+	return movies, errs
 }
 
-func fetchMovieData(id string) Movie {
-    url := fmt.Sprintf("%s/titles/%s?info=base_info", movieAPIBaseURL, id)
-    
-    log.Printf("Fetching movie data from URL: %s", url)
-    
-    req, _ := http.NewRequest("GET", url, nil)
-    req.Header.Add("x-rapidapi-host", movieAPIHost)
-    req.Header.Add("x-rapidapi-key", rapidAPIKey)
-    
-    res, err := http.DefaultClient.Do(req)
-    if err != nil {
-        log.Printf("Error fetching movie data for %s: %v", id, err)
-        return Movie{}
-    }
-    defer res.Body.Close()
-    
-    body, _ := io.ReadAll(res.Body)
-    log.Printf("Received movie data response: %s", string(body))
-    
-    var movieResp struct {
-        Results Movie `json:"results"`
-    }
-    err = json.Unmarshal(body, &movieResp)
-    if err != nil {
-        log.Printf("Error parsing movie data for %s: %v", id, err)
-        return Movie{}
-    }
-    
-    movie := movieResp.Results
-    movie.MainActors = getMainActors(id)
-    return movie
-}
+// fetchMovieWithErrors fetches a single movie's base data and streaming
+// options, tagging any failure with the stage it occurred in. A failed
+// movie fetch is fatal for that ID (nil movie returned); a failed
+// streaming lookup still returns the movie with empty StreamingOptions.
+func fetchMovieWithErrors(ctx context.Context, id string, filter streamingFilter) (*Movie, []FetchError) {
+	var errs []FetchError
 
-func fetchStreamingOptions(id string) []StreamingOption {
-    url := fmt.Sprintf("%s/shows/%s", streamingAPIBaseURL, id)
-    
-    log.Printf("Fetching streaming options from URL: %s", url)
-    
-    req, _ := http.NewRequest("GET", url, nil)
-    req.Header.Add("x-rapidapi-host", streamingAPIHost)
-    req.Header.Add("x-rapidapi-key", rapidAPIKey)
-    
-    res, err := http.DefaultClient.Do(req)
-    if err != nil {
-        log.Printf("Error fetching streaming options for %s: %v", id, err)
-        return nil
-    }
-    defer res.Body.Close()
-    
-    body, _ := io.ReadAll(res.Body)
-    log.Printf("Received streaming options response: %s", string(body))
-    
-    var streamingResp struct {
-        Results []StreamingOption `json:"results"`
-    }
-    err = json.Unmarshal(body, &streamingResp)
-    if err != nil {
-        log.Printf("Error parsing streaming options for %s: %v", id, err)
-        return nil
-    }
-    
-    return streamingResp.Results
-}
+	movie, err := dataFetcher.MovieData(ctx, id)
+	if err != nil {
+		return nil, append(errs, FetchError{ID: id, Stage: "movie", Message: err.Error()})
+	}
 
+	opts, err := dataFetcher.StreamingOptions(ctx, id)
+	if err != nil {
+		errs = append(errs, FetchError{ID: id, Stage: "streaming", Message: err.Error()})
+	} else {
+		movie.StreamingOptions = filter.apply(opts)
+	}
 
-func getMainActors(id string) []Actor {
-	url := fmt.Sprintf("%s/titles/%s/main_actors", movieAPIBaseURL, id)
+	if rec, err := movieStore.Get(ctx, id); err == nil {
+		mergeUserFields(&movie, rec)
+	} else if !errors.Is(err, moviestore.ErrNotFound) {
+		log.Printf("Error loading user fields for %s: %v", id, err)
+	}
 
-	req, _ := http.NewRequest("GET", url, nil)
-	req.Header.Add("x-rapidapi-host", movieAPIHost)
-	req.Header.Add("x-rapidapi-key", rapidAPIKey)
+	return &movie, errs
+}
 
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		log.Printf("Error fetching main actors for %s: %v", id, err)
-		return nil
+func fetchConcurrency() int {
+	if v := os.Getenv("FETCH_CONCURRENCY"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			return parsed
+		}
 	}
-	defer res.Body.Close()
+	return defaultFetchConcurrency
+}
 
-	body, _ := io.ReadAll(res.Body)
+func getMovie(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	filter := streamingFilterFromRequest(r)
 
-	var actorsResp struct {
-		Results []struct {
-			Name string `json:"name"`
-		} `json:"results"`
-	}
-	err = json.Unmarshal(body, &actorsResp)
-	if err != nil {
-		log.Printf("Error parsing main actors for %s: %v", id, err)
-		return nil
-	}
+	ctx, cancel := context.WithTimeout(r.Context(), fetchTimeout)
+	defer cancel()
 
-	var actors []Actor
-	for _, result := range actorsResp.Results {
-		actors = append(actors, Actor{Name: result.Name})
+	movie, errs := fetchMovieWithErrors(ctx, id, filter)
+	if movie == nil {
+		http.Error(w, errs[0].Message, http.StatusBadGateway)
+		return
 	}
 
-	return actors
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movie)
 }
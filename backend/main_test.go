@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/michaelamckanna/movieapp/backend/cache"
+	"github.com/michaelamckanna/movieapp/backend/fetcher"
+	"github.com/michaelamckanna/movieapp/backend/moviestore"
+)
+
+// titlesHandler fakes the upstream movie API: known IDs return valid
+// movie JSON, "tt-bad" returns a malformed body so its movie-stage fetch
+// fails, and every ID's main_actors lookup returns an empty list.
+func titlesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/titles/tt-bad":
+		w.Write([]byte("not json"))
+	case "/titles/tt1":
+		json.NewEncoder(w).Encode(map[string]any{
+			"results": map[string]any{"id": "tt1", "titleText": "Movie One"},
+		})
+	case "/titles/tt2":
+		json.NewEncoder(w).Encode(map[string]any{
+			"results": map[string]any{"id": "tt2", "titleText": "Movie Two"},
+		})
+	case "/titles/tt1/main_actors", "/titles/tt2/main_actors", "/titles/tt-bad/main_actors":
+		json.NewEncoder(w).Encode(map[string]any{"results": []any{}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestFetchMoviesConcurrentlyPartialFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(titlesHandler))
+	defer srv.Close()
+
+	store, err := moviestore.Open(filepath.Join(t.TempDir(), "movieapp.db"))
+	if err != nil {
+		t.Fatalf("moviestore.Open() error = %v", err)
+	}
+	defer store.Close()
+
+	origFetcher, origStore := dataFetcher, movieStore
+	t.Cleanup(func() { dataFetcher, movieStore = origFetcher, origStore })
+
+	dataFetcher = fetcher.New(fetcher.Config{
+		MovieAPIBaseURL: srv.URL,
+		RPS:             100,
+	}, cache.NewLRUStore(100))
+	movieStore = store
+
+	movies, errs := fetchMoviesConcurrently(context.Background(), []string{"tt1", "tt-bad", "tt2"}, streamingFilter{})
+
+	if len(errs) != 1 {
+		t.Fatalf("errs = %+v, want exactly 1 entry for tt-bad", errs)
+	}
+	if errs[0].ID != "tt-bad" || errs[0].Stage != "movie" {
+		t.Fatalf("errs[0] = %+v, want ID=tt-bad Stage=movie", errs[0])
+	}
+
+	gotIDs := make(map[string]bool, len(movies))
+	for _, m := range movies {
+		gotIDs[m.ID] = true
+	}
+	if len(movies) != 2 || !gotIDs["tt1"] || !gotIDs["tt2"] {
+		t.Fatalf("movies = %+v, want exactly tt1 and tt2", movies)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/michaelamckanna/movieapp/backend/moviestore"
+)
+
+// movieStore persists user-curated fields (notes, tags, personal rating,
+// watched status) that aren't part of the upstream RapidAPI data.
+var movieStore *moviestore.Store
+
+func openMovieStore() *moviestore.Store {
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = "movieapp.db"
+	}
+
+	store, err := moviestore.Open(path)
+	if err != nil {
+		log.Fatalf("Error opening movie store at %s: %v", path, err)
+	}
+	return store
+}
+
+// mergeUserFields overlays movie with the user-curated fields from rec.
+func mergeUserFields(movie *Movie, rec moviestore.Record) {
+	movie.Notes = rec.Notes
+	movie.Tags = rec.Tags
+	movie.Watched = rec.Watched
+	movie.Rating = rec.Rating
+	movie.WatchedAt = rec.WatchedAt
+}
+
+type createMovieRequest struct {
+	Title   string   `json:"title"`
+	ImdbID  string   `json:"imdbID"`
+	Notes   string   `json:"notes"`
+	Tags    []string `json:"tags"`
+	Watched bool     `json:"watched"`
+	Rating  *float64 `json:"rating"`
+}
+
+// postMovie handles POST /movies, adding a user-curated record keyed by
+// imdbID. Base movie data is hydrated lazily the next time this ID is
+// fetched.
+func postMovie(w http.ResponseWriter, r *http.Request) {
+	var req createMovieRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ImdbID == "" {
+		http.Error(w, "imdbID is required", http.StatusBadRequest)
+		return
+	}
+
+	rec, err := movieStore.Insert(r.Context(), moviestore.Record{
+		ID:      req.ImdbID,
+		Title:   req.Title,
+		ImdbID:  req.ImdbID,
+		Notes:   req.Notes,
+		Tags:    req.Tags,
+		Watched: req.Watched,
+		Rating:  req.Rating,
+	})
+	if err != nil {
+		if errors.Is(err, moviestore.ErrConflict) {
+			http.Error(w, "movie already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rec)
+}
+
+type patchMovieRequest struct {
+	Notes   *string  `json:"notes"`
+	Tags    []string `json:"tags"`
+	Watched *bool    `json:"watched"`
+	Rating  *float64 `json:"rating"`
+}
+
+// patchMovie handles PATCH /movies/{id}, updating the user-curated
+// fields for an existing record.
+func patchMovie(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req patchMovieRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	rec, err := movieStore.Update(r.Context(), id, moviestore.Patch{
+		Notes:   req.Notes,
+		Tags:    req.Tags,
+		Watched: req.Watched,
+		Rating:  req.Rating,
+	})
+	if err != nil {
+		if errors.Is(err, moviestore.ErrNotFound) {
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rec)
+}
+
+// deleteMovie handles DELETE /movies/{id}, removing the user-curated
+// record. It doesn't touch anything in the upstream data cache.
+func deleteMovie(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := movieStore.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, moviestore.ErrNotFound) {
+			http.Error(w, "movie not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listMovies handles GET /movies when it's called with filters instead
+// of explicit IDs, returning user-curated records hydrated with whatever
+// upstream data is cached or fetchable for them.
+func listMovies(w http.ResponseWriter, r *http.Request) {
+	filter := moviestore.Filter{
+		Tag:   r.URL.Query().Get("tag"),
+		Query: r.URL.Query().Get("q"),
+	}
+	if v := r.URL.Query().Get("watched"); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			filter.Watched = &parsed
+		}
+	}
+	if v := r.URL.Query().Get("minRating"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			filter.MinRating = &parsed
+		}
+	}
+
+	records, err := movieStore.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), fetchTimeout)
+	defer cancel()
+	streamingFilter := streamingFilterFromRequest(r)
+
+	movies := make([]Movie, 0, len(records))
+	for _, rec := range records {
+		movie, err := dataFetcher.MovieData(ctx, rec.ID)
+		if err != nil {
+			log.Printf("Error hydrating movie data for %s: %v", rec.ID, err)
+			movie = Movie{ID: rec.ID, TitleText: rec.Title}
+		}
+		if opts, err := dataFetcher.StreamingOptions(ctx, rec.ID); err == nil {
+			movie.StreamingOptions = streamingFilter.apply(opts)
+		}
+		mergeUserFields(&movie, rec)
+		movies = append(movies, movie)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MoviesResponse{Movies: movies})
+}
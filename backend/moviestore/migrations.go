@@ -0,0 +1,56 @@
+package moviestore
+
+import "fmt"
+
+// migrations are applied in order on Open, each tracked by a row in
+// schema_migrations so restarting the service never re-runs one.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS movies (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		imdb_id TEXT NOT NULL,
+		notes TEXT NOT NULL DEFAULT '',
+		tags TEXT NOT NULL DEFAULT '',
+		watched INTEGER NOT NULL DEFAULT 0,
+		rating REAL,
+		watched_at DATETIME,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	)`,
+}
+
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return fmt.Errorf("moviestore: bootstrapping schema_migrations: %w", err)
+	}
+
+	for i, stmt := range migrations {
+		version := i + 1
+
+		var applied bool
+		err := s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)`, version).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("moviestore: checking migration %d: %w", version, err)
+		}
+		if applied {
+			continue
+		}
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("moviestore: starting migration %d: %w", version, err)
+		}
+		if _, err := tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("moviestore: applying migration %d: %w", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("moviestore: recording migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("moviestore: committing migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
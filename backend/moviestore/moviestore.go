@@ -0,0 +1,293 @@
+// Package moviestore persists user-curated movie records (notes, tags,
+// personal rating, watched status) in a local SQLite database, so the
+// service is more than a caching proxy in front of the upstream RapidAPI
+// data. Base movie data continues to be hydrated from the cache/upstream
+// fetch layer and merged with these records at the API layer.
+package moviestore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// ErrNotFound is returned when a record doesn't exist.
+var ErrNotFound = errors.New("moviestore: record not found")
+
+// ErrConflict is returned by Insert when a record with the same ID
+// already exists.
+var ErrConflict = errors.New("moviestore: record already exists")
+
+// Record is a user-curated movie entry. ID matches the upstream movie ID
+// (e.g. an IMDb title ID) so it lines up with /movies/{id}.
+type Record struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	ImdbID    string     `json:"imdbID"`
+	Notes     string     `json:"notes,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Watched   bool       `json:"watched"`
+	Rating    *float64   `json:"rating,omitempty"`
+	WatchedAt *time.Time `json:"watchedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Patch describes a partial update to a Record; nil fields are left
+// unchanged.
+type Patch struct {
+	Notes   *string
+	Tags    []string
+	Watched *bool
+	Rating  *float64
+}
+
+// Filter narrows List results. Zero values are ignored.
+type Filter struct {
+	Tag       string
+	Watched   *bool
+	MinRating *float64
+	Query     string
+}
+
+// Store is a SQLite-backed movie store. It's safe for concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// applies any pending migrations.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("moviestore: opening %s: %w", path, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("moviestore: connecting to %s: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Insert adds a new user-curated record. CreatedAt/UpdatedAt are stamped
+// with the current time regardless of what rec carries.
+func (s *Store) Insert(ctx context.Context, rec Record) (Record, error) {
+	now := time.Now().UTC()
+	rec.CreatedAt, rec.UpdatedAt = now, now
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO movies (id, title, imdb_id, notes, tags, watched, rating, watched_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.ID, rec.Title, rec.ImdbID, rec.Notes, joinTags(rec.Tags), rec.Watched, rec.Rating, rec.WatchedAt, rec.CreatedAt, rec.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueConstraintErr(err) {
+			return Record{}, ErrConflict
+		}
+		return Record{}, fmt.Errorf("moviestore: inserting %s: %w", rec.ID, err)
+	}
+	return rec, nil
+}
+
+// isUniqueConstraintErr reports whether err is a SQLite UNIQUE/PRIMARY
+// KEY constraint violation, e.g. from inserting a duplicate ID.
+func isUniqueConstraintErr(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// Get returns the record for id, or ErrNotFound if it doesn't exist.
+func (s *Store) Get(ctx context.Context, id string) (Record, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, title, imdb_id, notes, tags, watched, rating, watched_at, created_at, updated_at
+		FROM movies WHERE id = ?`, id)
+	return scanRecord(row)
+}
+
+// Update applies patch to the record for id and returns the updated
+// record. Toggling Watched to true stamps WatchedAt if it isn't already
+// set; toggling it to false clears WatchedAt.
+func (s *Store) Update(ctx context.Context, id string, patch Patch) (Record, error) {
+	rec, err := s.Get(ctx, id)
+	if err != nil {
+		return Record{}, err
+	}
+
+	if patch.Notes != nil {
+		rec.Notes = *patch.Notes
+	}
+	if patch.Tags != nil {
+		rec.Tags = patch.Tags
+	}
+	if patch.Rating != nil {
+		rec.Rating = patch.Rating
+	}
+	if patch.Watched != nil {
+		rec.Watched = *patch.Watched
+		switch {
+		case rec.Watched && rec.WatchedAt == nil:
+			now := time.Now().UTC()
+			rec.WatchedAt = &now
+		case !rec.Watched:
+			rec.WatchedAt = nil
+		}
+	}
+	rec.UpdatedAt = time.Now().UTC()
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE movies SET notes = ?, tags = ?, watched = ?, rating = ?, watched_at = ?, updated_at = ?
+		WHERE id = ?`,
+		rec.Notes, joinTags(rec.Tags), rec.Watched, rec.Rating, rec.WatchedAt, rec.UpdatedAt, id,
+	)
+	if err != nil {
+		return Record{}, fmt.Errorf("moviestore: updating %s: %w", id, err)
+	}
+	return rec, nil
+}
+
+// Delete removes the record for id, returning ErrNotFound if it didn't
+// exist.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM movies WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("moviestore: deleting %s: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns records matching filter, most recently updated first.
+func (s *Store) List(ctx context.Context, filter Filter) ([]Record, error) {
+	query := `SELECT id, title, imdb_id, notes, tags, watched, rating, watched_at, created_at, updated_at FROM movies WHERE 1 = 1`
+	var args []any
+
+	if filter.Tag != "" {
+		query += ` AND tags LIKE ?`
+		args = append(args, "%,"+filter.Tag+",%")
+	}
+	if filter.Watched != nil {
+		query += ` AND watched = ?`
+		args = append(args, *filter.Watched)
+	}
+	if filter.MinRating != nil {
+		query += ` AND rating >= ?`
+		args = append(args, *filter.MinRating)
+	}
+	if filter.Query != "" {
+		query += ` AND title LIKE ?`
+		args = append(args, "%"+filter.Query+"%")
+	}
+	query += ` ORDER BY updated_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("moviestore: listing: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// ListStale returns every record last updated before cutoff, for the
+// background refresh worker to pick up.
+func (s *Store) ListStale(ctx context.Context, cutoff time.Time) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, title, imdb_id, notes, tags, watched, rating, watched_at, created_at, updated_at
+		FROM movies WHERE updated_at < ? ORDER BY updated_at ASC`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("moviestore: listing stale records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		rec, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// Touch updates a record's updated_at timestamp without changing any
+// other field, so a refresh of the upstream data (not the user-curated
+// fields) still clears it from ListStale.
+func (s *Store) Touch(ctx context.Context, id string, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE movies SET updated_at = ? WHERE id = ?`, at, id)
+	if err != nil {
+		return fmt.Errorf("moviestore: touching %s: %w", id, err)
+	}
+	return nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecord(row scanner) (Record, error) {
+	var rec Record
+	var tags string
+	var watchedAt sql.NullTime
+	var rating sql.NullFloat64
+
+	err := row.Scan(&rec.ID, &rec.Title, &rec.ImdbID, &rec.Notes, &tags, &rec.Watched, &rating, &watchedAt, &rec.CreatedAt, &rec.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Record{}, ErrNotFound
+		}
+		return Record{}, fmt.Errorf("moviestore: scanning record: %w", err)
+	}
+
+	rec.Tags = splitTags(tags)
+	if rating.Valid {
+		rec.Rating = &rating.Float64
+	}
+	if watchedAt.Valid {
+		rec.WatchedAt = &watchedAt.Time
+	}
+	return rec, nil
+}
+
+// joinTags/splitTags store tags as a comma-delimited string padded with
+// leading/trailing commas, so filtering by a single tag can use a plain
+// LIKE '%,tag,%' without a JSON extension.
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+func splitTags(s string) []string {
+	s = strings.Trim(s, ",")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,174 @@
+package moviestore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "movieapp.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestInsertAndGet(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	rating := 9.0
+	rec, err := store.Insert(ctx, Record{
+		ID:     "tt0133093",
+		Title:  "The Matrix",
+		ImdbID: "tt0133093",
+		Tags:   []string{"sci-fi", "favorite"},
+		Rating: &rating,
+	})
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if rec.CreatedAt.IsZero() || rec.UpdatedAt.IsZero() {
+		t.Fatalf("Insert() did not stamp timestamps: %+v", rec)
+	}
+
+	got, err := store.Get(ctx, "tt0133093")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Title != "The Matrix" || len(got.Tags) != 2 || got.Rating == nil || *got.Rating != 9.0 {
+		t.Fatalf("Get() = %+v, want Matrix record with 2 tags and rating 9.0", got)
+	}
+}
+
+func TestInsertDuplicateID(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Insert(ctx, Record{ID: "tt1", Title: "Movie One", ImdbID: "tt1"}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if _, err := store.Insert(ctx, Record{ID: "tt1", Title: "Movie One Again", ImdbID: "tt1"}); err != ErrConflict {
+		t.Fatalf("Insert() of duplicate ID error = %v, want ErrConflict", err)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	store := openTestStore(t)
+
+	if _, err := store.Get(context.Background(), "missing"); err != ErrNotFound {
+		t.Fatalf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUpdateSetsWatchedAt(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Insert(ctx, Record{ID: "tt1", Title: "Movie One", ImdbID: "tt1"}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	watched := true
+	notes := "rewatched with friends"
+	updated, err := store.Update(ctx, "tt1", Patch{Watched: &watched, Notes: &notes})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.Watched || updated.WatchedAt == nil {
+		t.Fatalf("Update() = %+v, want watched=true with WatchedAt set", updated)
+	}
+	if updated.Notes != notes {
+		t.Fatalf("Update() Notes = %q, want %q", updated.Notes, notes)
+	}
+
+	notWatched := false
+	updated, err = store.Update(ctx, "tt1", Patch{Watched: &notWatched})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Watched || updated.WatchedAt != nil {
+		t.Fatalf("Update() = %+v, want watched=false with WatchedAt cleared", updated)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	highRating := 9.0
+	lowRating := 5.0
+	mustInsert := func(rec Record) {
+		t.Helper()
+		if _, err := store.Insert(ctx, rec); err != nil {
+			t.Fatalf("Insert(%s) error = %v", rec.ID, err)
+		}
+	}
+
+	mustInsert(Record{ID: "tt1", Title: "The Matrix", ImdbID: "tt1", Tags: []string{"sci-fi"}, Rating: &highRating, Watched: true})
+	mustInsert(Record{ID: "tt2", Title: "The Notebook", ImdbID: "tt2", Tags: []string{"romance"}, Rating: &lowRating, Watched: false})
+
+	results, err := store.List(ctx, Filter{Tag: "sci-fi"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "tt1" {
+		t.Fatalf("List(Tag=sci-fi) = %+v, want only tt1", results)
+	}
+
+	minRating := 7.0
+	results, err = store.List(ctx, Filter{MinRating: &minRating})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "tt1" {
+		t.Fatalf("List(MinRating=7) = %+v, want only tt1", results)
+	}
+
+	results, err = store.List(ctx, Filter{Query: "notebook"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "tt2" {
+		t.Fatalf("List(Query=notebook) = %+v, want only tt2", results)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Insert(ctx, Record{ID: "tt1", Title: "Movie One", ImdbID: "tt1"}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if err := store.Delete(ctx, "tt1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "tt1"); err != ErrNotFound {
+		t.Fatalf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "tt1"); err != ErrNotFound {
+		t.Fatalf("Delete() of already-deleted record error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "movieapp.db")
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.Close()
+
+	// Reopening an existing database must not fail or re-apply migrations.
+	store, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() on existing db error = %v", err)
+	}
+	defer store.Close()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+// streamingCountriesFromEnv parses STREAMING_COUNTRIES into the country
+// codes fetched (and cached) for every movie, so deployments only pay
+// for the locales they actually serve. Defaults to ["us"].
+func streamingCountriesFromEnv() []string {
+	return parseStreamingCountries(os.Getenv("STREAMING_COUNTRIES"))
+}
+
+func parseStreamingCountries(v string) []string {
+	var countries []string
+	for _, c := range strings.Split(v, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" {
+			countries = append(countries, c)
+		}
+	}
+	if len(countries) == 0 {
+		return []string{"us"}
+	}
+	return countries
+}
+
+// streamingFilter narrows the streaming options returned to callers down
+// to the country/type a client asked for via ?country= and ?type=,
+// without affecting what's fetched or cached.
+type streamingFilter struct {
+	country string
+	typ     string
+}
+
+func streamingFilterFromRequest(r *http.Request) streamingFilter {
+	return streamingFilter{
+		country: strings.ToLower(r.URL.Query().Get("country")),
+		typ:     strings.ToLower(r.URL.Query().Get("type")),
+	}
+}
+
+func (f streamingFilter) apply(opts []StreamingOption) []StreamingOption {
+	if f.country == "" && f.typ == "" {
+		return opts
+	}
+
+	var filtered []StreamingOption
+	for _, opt := range opts {
+		if f.country != "" && !strings.EqualFold(opt.Country, f.country) {
+			continue
+		}
+		if f.typ != "" && !strings.EqualFold(opt.Type, f.typ) {
+			continue
+		}
+		filtered = append(filtered, opt)
+	}
+	return filtered
+}